@@ -0,0 +1,43 @@
+package jsonnet
+
+// Config is the YAML shape for a single entry under
+// `provisioning/jsonnet/*.yaml`. It mirrors the structure already used by
+// the dashboards and datasources provisioners so the same `name`
+// discovery convention applies.
+type Config struct {
+	Name string `yaml:"name"`
+
+	// JPath lists additional directories searched for `import` and
+	// `importstr` statements. An import is first resolved against the
+	// directory of the file that contains it; after that the JPath
+	// directories are tried, the last one listed first, and the
+	// entrypoint's own directory last.
+	JPath []string `yaml:"jpath"`
+
+	// Entrypoints are the top-level .jsonnet/.libsonnet files to
+	// evaluate. Each must evaluate to a dashboard, datasource, folder,
+	// or alert rule object (or an array of such objects).
+	Entrypoints []string `yaml:"entrypoints"`
+
+	// ExtVars are exposed to evaluated files as external variables,
+	// equivalent to the jsonnet CLI's `--ext-str`/`--ext-code` flags.
+	ExtVars []ExtVar `yaml:"ext_vars"`
+
+	// TLAVars are passed as top-level arguments to entrypoints whose
+	// outermost value is a function, equivalent to `--tla-str`.
+	TLAVars []ExtVar `yaml:"tla_vars"`
+
+	// UpdateIntervalSeconds is read from the YAML but nothing uses it:
+	// entrypoints are re-evaluated only at startup and when a watched
+	// file changes, never on a timer.
+	UpdateIntervalSeconds int64 `yaml:"updateIntervalSeconds"`
+}
+
+// ExtVar is a single external or top-level-argument variable. Code is
+// raw jsonnet source (`--ext-code`/`--tla-code`); when Code is empty the
+// value is treated as a plain string (`--ext-str`/`--tla-str`).
+type ExtVar struct {
+	Name string `yaml:"name"`
+	Str  string `yaml:"str"`
+	Code string `yaml:"code"`
+}
@@ -0,0 +1,79 @@
+package jsonnet
+
+import (
+	"os"
+	"path/filepath"
+
+	jsonnet "github.com/google/go-jsonnet"
+)
+
+// ImportGraph maps each file that was visited during evaluation to the
+// entrypoints that (transitively) import it, so a change to a single
+// .libsonnet file re-renders only the entrypoints that actually depend on
+// it instead of the whole provisioning directory.
+type ImportGraph struct {
+	// dependents maps an imported file to the set of entrypoints that
+	// pulled it in, directly or transitively.
+	dependents map[string]map[string]bool
+}
+
+// NewImportGraph returns an empty graph.
+func NewImportGraph() *ImportGraph {
+	return &ImportGraph{dependents: map[string]map[string]bool{}}
+}
+
+// Track records that entrypoint was evaluated with jpath as its import
+// path, and updates the graph with every file it transitively imports.
+func (g *ImportGraph) Track(entrypoint string, jpath []string) error {
+	cache := jsonnet.MakeVM()
+	importer := &jsonnet.FileImporter{JPaths: append([]string{filepath.Dir(entrypoint)}, jpath...)}
+	cache.Importer(importer)
+
+	visited := map[string]bool{}
+	var visit func(path string) error
+	visit = func(path string) error {
+		if visited[path] {
+			return nil
+		}
+		visited[path] = true
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, imp := range extractImports(string(contents)) {
+			_, foundAt, err := importer.Import(path, imp)
+			if err != nil {
+				// Unresolvable imports are surfaced by the real
+				// evaluation pass; don't fail dependency tracking
+				// on them.
+				continue
+			}
+			if err := visit(foundAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(entrypoint); err != nil {
+		return err
+	}
+
+	for file := range visited {
+		if g.dependents[file] == nil {
+			g.dependents[file] = map[string]bool{}
+		}
+		g.dependents[file][entrypoint] = true
+	}
+	return nil
+}
+
+// AffectedEntrypoints returns the entrypoints that need re-rendering
+// because changedFile was modified.
+func (g *ImportGraph) AffectedEntrypoints(changedFile string) []string {
+	var out []string
+	for ep := range g.dependents[changedFile] {
+		out = append(out, ep)
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+package jsonnet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportGraphTracksTransitiveImports(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir, "lib.libsonnet", `{ title: "from lib" }`)
+	writeFile(t, dir, "mid.libsonnet", `import "lib.libsonnet"`)
+	writeFile(t, dir, "entry.jsonnet", `import "mid.libsonnet"`)
+
+	entrypoint := filepath.Join(dir, "entry.jsonnet")
+
+	graph := NewImportGraph()
+	require.NoError(t, graph.Track(entrypoint, nil))
+
+	require.ElementsMatch(t, []string{entrypoint}, graph.AffectedEntrypoints(filepath.Join(dir, "mid.libsonnet")))
+	require.ElementsMatch(t, []string{entrypoint}, graph.AffectedEntrypoints(filepath.Join(dir, "lib.libsonnet")))
+}
+
+func TestImportGraphUnrelatedFileNotAffected(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir, "lib.libsonnet", `{ title: "from lib" }`)
+	writeFile(t, dir, "entry.jsonnet", `import "lib.libsonnet"`)
+	writeFile(t, dir, "unrelated.libsonnet", `{}`)
+
+	entrypoint := filepath.Join(dir, "entry.jsonnet")
+
+	graph := NewImportGraph()
+	require.NoError(t, graph.Track(entrypoint, nil))
+
+	require.Empty(t, graph.AffectedEntrypoints(filepath.Join(dir, "unrelated.libsonnet")))
+}
+
+func writeFile(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644))
+}
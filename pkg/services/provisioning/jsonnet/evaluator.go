@@ -0,0 +1,59 @@
+package jsonnet
+
+import (
+	"fmt"
+	"path/filepath"
+
+	jsonnet "github.com/google/go-jsonnet"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+var logger = log.New("provisioning.jsonnet")
+
+// Evaluator renders jsonnet entrypoints into the canonical JSON model that
+// the dashboard, datasource, and folder provisioners already consume. It is
+// deliberately stateless between calls; callers that want incremental
+// re-evaluation should pair it with the import graph built by
+// ImportGraph.
+type Evaluator struct {
+	jpath []string
+}
+
+// NewEvaluator builds an Evaluator whose import search path is jpath,
+// looked up as described on Config.JPath.
+func NewEvaluator(jpath []string) *Evaluator {
+	return &Evaluator{jpath: jpath}
+}
+
+// Render evaluates entrypoint and returns its canonical JSON encoding. The
+// result may be a single JSON object or a JSON array, depending on what
+// entrypoint itself evaluates to.
+func (e *Evaluator) Render(entrypoint string, extVars, tlaVars []ExtVar) (string, error) {
+	vm := jsonnet.MakeVM()
+	vm.Importer(&jsonnet.FileImporter{
+		JPaths: append([]string{filepath.Dir(entrypoint)}, e.jpath...),
+	})
+
+	for _, v := range extVars {
+		if v.Code != "" {
+			vm.ExtCode(v.Name, v.Code)
+		} else {
+			vm.ExtVar(v.Name, v.Str)
+		}
+	}
+	for _, v := range tlaVars {
+		if v.Code != "" {
+			vm.TLACode(v.Name, v.Code)
+		} else {
+			vm.TLAVar(v.Name, v.Str)
+		}
+	}
+
+	out, err := vm.EvaluateFile(entrypoint)
+	if err != nil {
+		return "", fmt.Errorf("evaluating %q: %w", entrypoint, err)
+	}
+
+	return out, nil
+}
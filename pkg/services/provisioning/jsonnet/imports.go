@@ -0,0 +1,23 @@
+package jsonnet
+
+import "regexp"
+
+var importRe = regexp.MustCompile(`\b(?:import|importstr|importbin)\s+(?:"([^"]+)"|'([^']+)')`)
+
+// extractImports does a lightweight textual scan for import/importstr/
+// importbin statements with a double- or single-quoted path. It
+// intentionally doesn't fully parse the jsonnet AST: extra matches (e.g.
+// inside comments) are harmless, since unresolvable entries are skipped
+// by the caller. Verbatim (@"...") and text-block paths are not matched.
+func extractImports(src string) []string {
+	matches := importRe.FindAllStringSubmatch(src, -1)
+	out := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if m[1] != "" {
+			out = append(out, m[1])
+		} else {
+			out = append(out, m[2])
+		}
+	}
+	return out
+}
@@ -0,0 +1,37 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractImports(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "no imports",
+			src:  `{ foo: "bar" }`,
+			want: nil,
+		},
+		{
+			name: "import and importstr",
+			src:  `local lib = import "lib.libsonnet"; { text: importstr "readme.txt" }`,
+			want: []string{"lib.libsonnet", "readme.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractImports(tt.src)
+			if tt.want == nil {
+				require.Empty(t, got)
+				return
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package jsonnet
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/grafana/grafana/pkg/infra/fs"
+)
+
+// Provisioner reads jsonnet/*.yaml config files, renders their
+// entrypoints, and hands each rendered Resource off to targets -- the
+// same dashboard/datasource/folder/alert-rule provisioning pipeline used
+// by the YAML- and JSON-based provisioners.
+type Provisioner struct {
+	configPath string
+	targets    Targets
+	graph      *ImportGraph
+}
+
+// New creates a Provisioner that reads config files from configPath and
+// applies each rendered entrypoint's resources via targets.
+func New(configPath string, targets Targets) *Provisioner {
+	return &Provisioner{
+		configPath: configPath,
+		targets:    targets,
+		graph:      NewImportGraph(),
+	}
+}
+
+// Provision evaluates every configured entrypoint once and applies the
+// result. It is called at startup before the file-watcher is armed.
+func (p *Provisioner) Provision(ctx context.Context) error {
+	configs, err := p.readConfigs()
+	if err != nil {
+		return err
+	}
+
+	for _, cfg := range configs {
+		eval := NewEvaluator(cfg.JPath)
+		for _, entrypoint := range cfg.Entrypoints {
+			if err := p.renderApplyAndTrack(ctx, eval, cfg, entrypoint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// OnFileChanged is registered with the provisioning file-watcher. It
+// re-renders only the entrypoints whose import graph includes path, and
+// re-tracks them afterwards so imports added since the last render are
+// picked up for the next change.
+func (p *Provisioner) OnFileChanged(ctx context.Context, path string) error {
+	configs, err := p.readConfigs()
+	if err != nil {
+		return err
+	}
+
+	for _, cfg := range configs {
+		eval := NewEvaluator(cfg.JPath)
+		affected := p.graph.AffectedEntrypoints(path)
+		if len(affected) == 0 {
+			// path isn't in the import graph (e.g. a config file,
+			// or a file nothing imported at the last render); fall
+			// back to re-rendering every entrypoint in this config.
+			affected = cfg.Entrypoints
+		}
+		for _, entrypoint := range affected {
+			if err := p.renderApplyAndTrack(ctx, eval, cfg, entrypoint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Provisioner) renderApplyAndTrack(ctx context.Context, eval *Evaluator, cfg *Config, entrypoint string) error {
+	if err := p.renderAndApply(ctx, eval, cfg, entrypoint); err != nil {
+		return err
+	}
+	if err := p.graph.Track(entrypoint, cfg.JPath); err != nil {
+		logger.Warn("failed building jsonnet import graph", "entrypoint", entrypoint, "err", err)
+	}
+	return nil
+}
+
+func (p *Provisioner) renderAndApply(ctx context.Context, eval *Evaluator, cfg *Config, entrypoint string) error {
+	out, err := eval.Render(entrypoint, cfg.ExtVars, cfg.TLAVars)
+	if err != nil {
+		return fmt.Errorf("rendering %q: %w", entrypoint, err)
+	}
+
+	resources, err := ParseResources(out)
+	if err != nil {
+		return fmt.Errorf("%q: %w", entrypoint, err)
+	}
+
+	for _, resource := range resources {
+		if err := Apply(ctx, p.targets, resource); err != nil {
+			return fmt.Errorf("applying %s from %q: %w", resource.Kind, entrypoint, err)
+		}
+	}
+	return nil
+}
+
+func (p *Provisioner) readConfigs() ([]*Config, error) {
+	files, err := ioutil.ReadDir(p.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []*Config
+	for _, file := range files {
+		if file.IsDir() || !fs.HasYamlOrYmlExtension(file.Name()) {
+			continue
+		}
+
+		raw, err := ioutil.ReadFile(filepath.Join(p.configPath, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var cfg Config
+		if err := yaml.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing %q: %w", file.Name(), err)
+		}
+		configs = append(configs, &cfg)
+	}
+	return configs, nil
+}
@@ -0,0 +1,108 @@
+package jsonnet
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTargets struct {
+	dashboards  []json.RawMessage
+	datasources []json.RawMessage
+	folders     []json.RawMessage
+	alertRules  []json.RawMessage
+}
+
+func (f *fakeTargets) ApplyDashboard(ctx context.Context, spec json.RawMessage) error {
+	f.dashboards = append(f.dashboards, spec)
+	return nil
+}
+
+func (f *fakeTargets) ApplyDatasource(ctx context.Context, spec json.RawMessage) error {
+	f.datasources = append(f.datasources, spec)
+	return nil
+}
+
+func (f *fakeTargets) ApplyFolder(ctx context.Context, spec json.RawMessage) error {
+	f.folders = append(f.folders, spec)
+	return nil
+}
+
+func (f *fakeTargets) ApplyAlertRule(ctx context.Context, spec json.RawMessage) error {
+	f.alertRules = append(f.alertRules, spec)
+	return nil
+}
+
+func TestProvisionRendersAndAppliesEachResource(t *testing.T) {
+	configDir := t.TempDir()
+
+	writeFile(t, configDir, "dashboard.jsonnet", `[
+		{ kind: "Dashboard", spec: { title: "from jsonnet" } },
+		{ kind: "Datasource", spec: { name: "prometheus" } },
+	]`)
+	writeFile(t, configDir, "jsonnet.yaml", `
+name: jsonnet
+entrypoints:
+  - `+filepath.Join(configDir, "dashboard.jsonnet")+`
+`)
+
+	targets := &fakeTargets{}
+	p := New(configDir, targets)
+
+	require.NoError(t, p.Provision(context.Background()))
+
+	require.Len(t, targets.dashboards, 1)
+	require.JSONEq(t, `{"title":"from jsonnet"}`, string(targets.dashboards[0]))
+	require.Len(t, targets.datasources, 1)
+	require.JSONEq(t, `{"name":"prometheus"}`, string(targets.datasources[0]))
+}
+
+func TestOnFileChangedRetracksImportsAfterRender(t *testing.T) {
+	configDir := t.TempDir()
+
+	entrypoint := filepath.Join(configDir, "dashboard.jsonnet")
+	libPath := filepath.Join(configDir, "title.libsonnet")
+
+	writeFile(t, configDir, "title.libsonnet", `"first title"`)
+	writeFile(t, configDir, "dashboard.jsonnet", `{ kind: "Dashboard", spec: { title: import "title.libsonnet" } }`)
+	writeFile(t, configDir, "jsonnet.yaml", `
+name: jsonnet
+entrypoints:
+  - `+entrypoint+`
+`)
+
+	targets := &fakeTargets{}
+	p := New(configDir, targets)
+	require.NoError(t, p.Provision(context.Background()))
+	require.JSONEq(t, `{"title":"first title"}`, string(targets.dashboards[0]))
+
+	// title.libsonnet was tracked when the entrypoint was first
+	// provisioned -- edit it and confirm the tracked dependency triggers
+	// a re-render.
+	require.NoError(t, os.WriteFile(libPath, []byte(`"second title"`), 0o644))
+	require.NoError(t, p.OnFileChanged(context.Background(), libPath))
+	require.Len(t, targets.dashboards, 2)
+	require.JSONEq(t, `{"title":"second title"}`, string(targets.dashboards[1]))
+
+	// A second change to the same imported file re-renders again.
+	require.NoError(t, os.WriteFile(libPath, []byte(`"third title"`), 0o644))
+	require.NoError(t, p.OnFileChanged(context.Background(), libPath))
+	require.Len(t, targets.dashboards, 3)
+	require.JSONEq(t, `{"title":"third title"}`, string(targets.dashboards[2]))
+
+	// The entrypoint now imports a file it didn't import before; the
+	// render triggered by its own change must re-track it, so the new
+	// import is in the graph afterwards.
+	subtitlePath := filepath.Join(configDir, "subtitle.libsonnet")
+	writeFile(t, configDir, "subtitle.libsonnet", `"fourth title"`)
+	writeFile(t, configDir, "dashboard.jsonnet", `{ kind: "Dashboard", spec: { title: import "subtitle.libsonnet" } }`)
+	require.Empty(t, p.graph.AffectedEntrypoints(subtitlePath))
+	require.NoError(t, p.OnFileChanged(context.Background(), entrypoint))
+	require.Len(t, targets.dashboards, 4)
+	require.JSONEq(t, `{"title":"fourth title"}`, string(targets.dashboards[3]))
+	require.ElementsMatch(t, []string{entrypoint}, p.graph.AffectedEntrypoints(subtitlePath))
+}
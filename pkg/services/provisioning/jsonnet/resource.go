@@ -0,0 +1,78 @@
+package jsonnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Resource kinds a jsonnet entrypoint may evaluate to.
+const (
+	KindDashboard  = "Dashboard"
+	KindDatasource = "Datasource"
+	KindFolder     = "Folder"
+	KindAlertRule  = "AlertRule"
+)
+
+// Resource is the canonical envelope every jsonnet entrypoint must
+// evaluate to (or a JSON array of): a Kind discriminator plus the
+// kind-specific spec, so a single jsonnet file can mix dashboards,
+// datasources, folders, and alert rules and have each flow through the
+// provisioner that already knows how to apply it.
+type Resource struct {
+	Kind string          `json:"kind"`
+	Spec json.RawMessage `json:"spec"`
+}
+
+// ParseResources decodes rendered jsonnet output -- either a single
+// Resource object or a JSON array of Resource objects -- into a uniform
+// slice.
+func ParseResources(rendered string) ([]Resource, error) {
+	trimmed := strings.TrimSpace(rendered)
+	if trimmed == "" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(trimmed, "[") {
+		var resources []Resource
+		if err := json.Unmarshal([]byte(trimmed), &resources); err != nil {
+			return nil, fmt.Errorf("parsing rendered resources: %w", err)
+		}
+		return resources, nil
+	}
+
+	var resource Resource
+	if err := json.Unmarshal([]byte(trimmed), &resource); err != nil {
+		return nil, fmt.Errorf("parsing rendered resource: %w", err)
+	}
+	return []Resource{resource}, nil
+}
+
+// Targets is the set of existing, non-jsonnet provisioners a rendered
+// Resource is handed off to based on its Kind -- the same dashboard,
+// datasource, folder, and alert rule provisioning paths the YAML- and
+// JSON-based provisioners already use. grafanaTargets (service.go)
+// implements this against the real services; tests use fakes.
+type Targets interface {
+	ApplyDashboard(ctx context.Context, spec json.RawMessage) error
+	ApplyDatasource(ctx context.Context, spec json.RawMessage) error
+	ApplyFolder(ctx context.Context, spec json.RawMessage) error
+	ApplyAlertRule(ctx context.Context, spec json.RawMessage) error
+}
+
+// Apply dispatches resource to the Targets method matching its Kind.
+func Apply(ctx context.Context, targets Targets, resource Resource) error {
+	switch resource.Kind {
+	case KindDashboard:
+		return targets.ApplyDashboard(ctx, resource.Spec)
+	case KindDatasource:
+		return targets.ApplyDatasource(ctx, resource.Spec)
+	case KindFolder:
+		return targets.ApplyFolder(ctx, resource.Spec)
+	case KindAlertRule:
+		return targets.ApplyAlertRule(ctx, resource.Spec)
+	default:
+		return fmt.Errorf("unknown resource kind %q", resource.Kind)
+	}
+}
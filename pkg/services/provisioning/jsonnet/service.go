@@ -0,0 +1,130 @@
+package jsonnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/grafana/grafana/pkg/registry"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func init() {
+	registry.RegisterService(&Service{})
+}
+
+// Service is the background service that provisions dashboards,
+// datasources, folders, and alert rules from Jsonnet sources under
+// <provisioning path>/jsonnet at startup, then re-renders the affected
+// entrypoints whenever a watched file changes. It is started the same
+// way as the other provisioning services: the registry constructs it,
+// calls Init, and then runs it for the lifetime of the server.
+type Service struct {
+	Cfg                   *setting.Cfg                             `inject:""`
+	DashboardProvisioning dashboards.DashboardProvisioningService  `inject:""`
+	DatasourceService     datasources.DataSourceService            `inject:""`
+	FolderService         folder.Service                           `inject:""`
+	AlertRuleService      *provisioning.AlertRuleService           `inject:""`
+
+	provisioner *Provisioner
+}
+
+// Init wires up the Provisioner against the real dashboard, datasource,
+// folder, and alert-rule provisioning paths; it is called by the
+// registry before Run.
+func (s *Service) Init() error {
+	configPath := filepath.Join(s.Cfg.ProvisioningPath, "jsonnet")
+	targets := &grafanaTargets{
+		dashboards:  s.DashboardProvisioning,
+		datasources: s.DatasourceService,
+		folders:     s.FolderService,
+		alertRules:  s.AlertRuleService,
+	}
+	s.provisioner = New(configPath, targets)
+	return nil
+}
+
+// Run provisions every configured entrypoint once, then blocks watching
+// configPath for changes until ctx is canceled.
+func (s *Service) Run(ctx context.Context) error {
+	if err := s.provisioner.Provision(ctx); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	configPath := filepath.Join(s.Cfg.ProvisioningPath, "jsonnet")
+	if err := watcher.Add(configPath); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-watcher.Events:
+			if err := s.provisioner.OnFileChanged(ctx, event.Name); err != nil {
+				logger.Warn("failed re-rendering after jsonnet file change", "file", event.Name, "err", err)
+			}
+		case err := <-watcher.Errors:
+			logger.Warn("jsonnet provisioning watcher error", "err", err)
+		}
+	}
+}
+
+// grafanaTargets implements Targets against the real dashboard,
+// datasource, folder, and alert-rule services, so a rendered Resource
+// flows through the exact same apply path the YAML/JSON provisioners use.
+type grafanaTargets struct {
+	dashboards  dashboards.DashboardProvisioningService
+	datasources datasources.DataSourceService
+	folders     folder.Service
+	alertRules  *provisioning.AlertRuleService
+}
+
+func (t *grafanaTargets) ApplyDashboard(ctx context.Context, spec json.RawMessage) error {
+	var dto dashboards.SaveDashboardDTO
+	if err := json.Unmarshal(spec, &dto); err != nil {
+		return fmt.Errorf("decoding dashboard spec: %w", err)
+	}
+	_, err := t.dashboards.SaveProvisionedDashboard(ctx, &dto, &dashboards.DashboardProvisioning{Name: "jsonnet"})
+	return err
+}
+
+func (t *grafanaTargets) ApplyDatasource(ctx context.Context, spec json.RawMessage) error {
+	var cmd datasources.AddDataSourceCommand
+	if err := json.Unmarshal(spec, &cmd); err != nil {
+		return fmt.Errorf("decoding datasource spec: %w", err)
+	}
+	_, err := t.datasources.AddDataSource(ctx, &cmd)
+	return err
+}
+
+func (t *grafanaTargets) ApplyFolder(ctx context.Context, spec json.RawMessage) error {
+	var cmd folder.CreateFolderCommand
+	if err := json.Unmarshal(spec, &cmd); err != nil {
+		return fmt.Errorf("decoding folder spec: %w", err)
+	}
+	_, err := t.folders.Create(ctx, &cmd)
+	return err
+}
+
+func (t *grafanaTargets) ApplyAlertRule(ctx context.Context, spec json.RawMessage) error {
+	var rule provisioning.AlertRule
+	if err := json.Unmarshal(spec, &rule); err != nil {
+		return fmt.Errorf("decoding alert rule spec: %w", err)
+	}
+	_, err := t.alertRules.CreateAlertRule(ctx, rule, provisioning.ProvenanceFile, 0)
+	return err
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// debouncedBuilder coalesces rapid Trigger calls into a single build,
+// cancelling any build already in flight so a fast sequence of file
+// saves never queues up stale work.
+type debouncedBuilder struct {
+	build    func(ctx context.Context) error
+	debounce time.Duration
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	cancel  context.CancelFunc
+	gen     uint64
+	results chan error
+	closed  bool
+}
+
+func newDebouncedBuilder(debounce time.Duration, build func(ctx context.Context) error) *debouncedBuilder {
+	return &debouncedBuilder{
+		build:    build,
+		debounce: debounce,
+		results:  make(chan error, 1),
+	}
+}
+
+// Trigger schedules a build after the debounce window elapses, resetting
+// the window if called again before it fires and cancelling any build
+// that is already running.
+func (b *debouncedBuilder) Trigger() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	if b.cancel != nil {
+		b.cancel()
+		b.cancel = nil
+	}
+
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	b.timer = time.AfterFunc(b.debounce, b.run)
+}
+
+func (b *debouncedBuilder) run() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b.gen++
+	gen := b.gen
+	b.cancel = cancel
+	b.mu.Unlock()
+
+	err := b.build(ctx)
+
+	b.mu.Lock()
+	// Only clear the cancel func if no newer run has already
+	// replaced it -- otherwise a stale build finishing after being
+	// superseded would wipe out the newer build's cancel func.
+	if b.gen == gen {
+		b.cancel = nil
+	}
+	b.mu.Unlock()
+
+	if ctx.Err() != nil {
+		// superseded by a newer Trigger; don't report a stale result.
+		return
+	}
+
+	select {
+	case b.results <- err:
+	default:
+		// drop the oldest unread result rather than block the builder.
+		select {
+		case <-b.results:
+		default:
+		}
+		b.results <- err
+	}
+}
+
+// Results streams one error (nil on success) per completed, non-stale
+// build. It buffers a single result: one that is still unread when the
+// next build completes is dropped.
+func (b *debouncedBuilder) Results() <-chan error {
+	return b.results
+}
+
+func (b *debouncedBuilder) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	if b.cancel != nil {
+		b.cancel()
+	}
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	close(b.results)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDebouncedBuilderCoalescesRapidTriggers(t *testing.T) {
+	var calls int32
+	builder := newDebouncedBuilder(20*time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	defer builder.Close()
+
+	for i := 0; i < 5; i++ {
+		builder.Trigger()
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	select {
+	case err := <-builder.Results():
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for build result")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("build ran %d times, want 1", got)
+	}
+}
+
+func TestDebouncedBuilderCancelsInFlightBuild(t *testing.T) {
+	started := make(chan struct{}, 2)
+	firstCtxCanceled := make(chan struct{})
+
+	var callCount int32
+	builder := newDebouncedBuilder(5*time.Millisecond, func(ctx context.Context) error {
+		n := atomic.AddInt32(&callCount, 1)
+		started <- struct{}{}
+		if n == 1 {
+			<-ctx.Done()
+			close(firstCtxCanceled)
+		}
+		return nil
+	})
+	defer builder.Close()
+
+	builder.Trigger()
+	<-started // first build is running
+
+	builder.Trigger() // must cancel the first build's context
+
+	select {
+	case <-firstCtxCanceled:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the first build's context to be canceled")
+	}
+
+	select {
+	case <-started: // the second, superseding build runs
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for superseding build to start")
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Subsystem identifies which part of the dev stack a BuildEvent came
+// from.
+type Subsystem string
+
+const (
+	SubsystemBackend  Subsystem = "backend"
+	SubsystemFrontend Subsystem = "frontend"
+	SubsystemPlugins  Subsystem = "plugins"
+)
+
+// Status is the outcome of a single build attempt.
+type Status string
+
+const (
+	StatusStarted   Status = "started"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// BuildEvent is the structured payload pushed to every connected
+// WebSocket client so the UI can render a toast without parsing raw
+// build logs.
+type BuildEvent struct {
+	Subsystem Subsystem `json:"subsystem"`
+	Status    Status    `json:"status"`
+	Message   string    `json:"message,omitempty"`
+}
+
+var upgrader = websocket.Upgrader{
+	// Every origin is accepted. devserver listens on -addr, which
+	// defaults to loopback (127.0.0.1); nothing else restricts who can
+	// connect.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// eventHub fans out BuildEvents to every connected WebSocket client and
+// also serves as the http.Handler for the /debug/devserver/events
+// endpoint.
+type eventHub struct {
+	mu      sync.Mutex
+	clients map[*websocket.Conn]bool
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{clients: map[*websocket.Conn]bool{}}
+}
+
+func (h *eventHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	h.clients[conn] = true
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete(h.clients, conn)
+		h.mu.Unlock()
+		conn.Close()
+	}()
+
+	// We don't expect messages from the client; block on reads purely
+	// to detect disconnects.
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (h *eventHub) Broadcast(event BuildEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for conn := range h.clients {
+		_ = conn.WriteMessage(websocket.TextMessage, payload)
+	}
+}
@@ -0,0 +1,53 @@
+// Command devserver supervises the backend, frontend webpack, and plugin
+// rebuilds used during local development, and broadcasts structured JSON
+// build events over a local WebSocket so the Grafana UI can show a
+// toast-style "backend restarted"/"build failed" indicator. It replaces
+// the ad-hoc `bra` invocation implied by the tools.go build import with a
+// single `make run-dev` entry point.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"net/http"
+)
+
+func main() {
+	var (
+		addr       = flag.String("addr", "127.0.0.1:0", "address devserver's HTTP/WebSocket endpoint listens on")
+		backendDir = flag.String("backend-dir", ".", "directory to watch and `go build` for backend changes")
+		pluginsDir = flag.String("plugins-dir", "plugins-bundled", "directory tree containing plugin sources to rebuild")
+		webpack    = flag.String("webpack-cmd", "yarn start", "command used to run the frontend webpack dev server")
+	)
+	flag.Parse()
+
+	hub := newEventHub()
+
+	supervisor := newSupervisor(hub, supervisorConfig{
+		backendDir: *backendDir,
+		pluginsDir: *pluginsDir,
+		webpackCmd: *webpack,
+	})
+	if err := supervisor.Start(); err != nil {
+		log.Fatalf("devserver: starting supervisor: %v", err)
+	}
+	defer supervisor.Stop()
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/devserver/events", hub)
+	mux.HandleFunc("/debug/devserver/status", supervisor.statusHandler)
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("devserver: listening on %s: %v", *addr, err)
+	}
+
+	// *addr defaults to 127.0.0.1:0 so devserver doesn't collide with
+	// other instances; log the port the OS actually assigned so the UI
+	// knows where to connect.
+	log.Printf("devserver: listening on %s", listener.Addr())
+	if err := http.Serve(listener, mux); err != nil {
+		log.Fatalf("devserver: %v", err)
+	}
+}
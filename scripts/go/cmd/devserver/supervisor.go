@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// skipWatchDirs are directory names whose subtrees are never worth
+// watching: they're either huge, vendored, or VCS metadata.
+var skipWatchDirs = map[string]bool{
+	"node_modules": true,
+	".git":         true,
+	"vendor":       true,
+	"dist":         true,
+}
+
+type supervisorConfig struct {
+	backendDir string
+	pluginsDir string
+	webpackCmd string
+
+	// debounce is the quiet period a subsystem's filesystem events must
+	// satisfy before a rebuild is triggered. Defaults to 300ms.
+	debounce time.Duration
+}
+
+// supervisor owns one debouncedBuilder each for the backend and plugins
+// subsystems (the frontend runs webpack's own watcher, see runFrontend)
+// and reports their outcomes through the shared eventHub.
+type supervisor struct {
+	hub     *eventHub
+	cfg     supervisorConfig
+	watcher *fsnotify.Watcher
+
+	mu       sync.Mutex
+	builders map[Subsystem]*debouncedBuilder
+	lastSeen map[Subsystem]BuildEvent
+}
+
+func newSupervisor(hub *eventHub, cfg supervisorConfig) *supervisor {
+	if cfg.debounce == 0 {
+		cfg.debounce = 300 * time.Millisecond
+	}
+	return &supervisor{
+		hub:      hub,
+		cfg:      cfg,
+		builders: map[Subsystem]*debouncedBuilder{},
+		lastSeen: map[Subsystem]BuildEvent{},
+	}
+}
+
+func (s *supervisor) Start() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	s.watcher = watcher
+
+	s.builders[SubsystemBackend] = newDebouncedBuilder(s.cfg.debounce, func(ctx context.Context) error {
+		return runCmd(ctx, s.cfg.backendDir, "go", "build", "./...")
+	})
+	s.builders[SubsystemPlugins] = newDebouncedBuilder(s.cfg.debounce, func(ctx context.Context) error {
+		return runCmd(ctx, s.cfg.pluginsDir, "go", "build", "./...")
+	})
+
+	for subsystem, builder := range s.builders {
+		subsystem, builder := subsystem, builder
+		go s.reportResults(subsystem, builder)
+	}
+
+	if err := watchTree(watcher, s.cfg.backendDir); err != nil {
+		return err
+	}
+	if err := watchTree(watcher, s.cfg.pluginsDir); err != nil {
+		return err
+	}
+
+	go s.watchEvents()
+	go s.runFrontend()
+
+	// Kick off an initial build of everything so the indicator has a
+	// known-good state before the first file change.
+	for _, builder := range s.builders {
+		builder.Trigger()
+	}
+	return nil
+}
+
+// watchTree adds root and every non-skipped subdirectory beneath it to
+// watcher. fsnotify only watches the directories it's explicitly told
+// about, not their descendants, so backend/plugin trees need every
+// package directory registered individually.
+func watchTree(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if skipWatchDirs[info.Name()] {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// runFrontend starts the frontend webpack dev server once and keeps it
+// running for the life of the supervisor: webpack watches its own
+// sources, so unlike the backend/plugin builders it isn't retriggered by
+// devserver's own filesystem watcher.
+func (s *supervisor) runFrontend() {
+	parts := strings.Fields(s.cfg.webpackCmd)
+	if len(parts) == 0 {
+		return
+	}
+
+	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd.Dir = "."
+
+	if err := cmd.Start(); err != nil {
+		s.publish(SubsystemFrontend, BuildEvent{Subsystem: SubsystemFrontend, Status: StatusFailed, Message: err.Error()})
+		return
+	}
+	s.publish(SubsystemFrontend, BuildEvent{Subsystem: SubsystemFrontend, Status: StatusStarted})
+
+	if err := cmd.Wait(); err != nil {
+		s.publish(SubsystemFrontend, BuildEvent{Subsystem: SubsystemFrontend, Status: StatusFailed, Message: err.Error()})
+	}
+}
+
+func (s *supervisor) publish(subsystem Subsystem, event BuildEvent) {
+	s.mu.Lock()
+	s.lastSeen[subsystem] = event
+	s.mu.Unlock()
+	s.hub.Broadcast(event)
+}
+
+func (s *supervisor) Stop() {
+	if s.watcher != nil {
+		s.watcher.Close()
+	}
+	for _, builder := range s.builders {
+		builder.Close()
+	}
+}
+
+func (s *supervisor) watchEvents() {
+	for event := range s.watcher.Events {
+		subsystem := s.subsystemFor(event.Name)
+		if builder, ok := s.builders[subsystem]; ok {
+			builder.Trigger()
+		}
+	}
+}
+
+func (s *supervisor) subsystemFor(path string) Subsystem {
+	if strings.HasPrefix(path, s.cfg.pluginsDir) {
+		return SubsystemPlugins
+	}
+	return SubsystemBackend
+}
+
+func (s *supervisor) reportResults(subsystem Subsystem, builder *debouncedBuilder) {
+	for result := range builder.Results() {
+		event := BuildEvent{Subsystem: subsystem, Status: StatusSucceeded}
+		if result != nil {
+			event.Status = StatusFailed
+			event.Message = result.Error()
+		}
+		s.publish(subsystem, event)
+	}
+}
+
+func (s *supervisor) statusHandler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.lastSeen)
+}
+
+func runCmd(ctx context.Context, dir, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return &buildError{output: string(out), cause: err}
+	}
+	return nil
+}
+
+type buildError struct {
+	output string
+	cause  error
+}
+
+func (e *buildError) Error() string {
+	if e.output == "" {
+		return e.cause.Error()
+	}
+	return e.output
+}
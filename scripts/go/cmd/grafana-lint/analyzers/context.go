@@ -0,0 +1,94 @@
+package analyzers
+
+import (
+	"go/ast"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// ContextPropagation flags pkg/api handlers that call into a service or
+// store method without forwarding the *models.ReqContext's request
+// context, which breaks request-scoped tracing and cancellation.
+func ContextPropagation() *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name: "grafanacontext",
+		Doc:  "reports pkg/api handlers that drop the request context when calling services",
+		Run:  runContextPropagation,
+	}
+}
+
+func runContextPropagation(pass *analysis.Pass) (interface{}, error) {
+	for _, file := range pass.Files {
+		if !isAPIHandlerFile(pass.Fset.File(file.Pos()).Name()) {
+			continue
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if !looksLikeServiceCall(sel.Sel.Name) {
+				return true
+			}
+			if !hasContextArg(call.Args) {
+				pass.Reportf(call.Pos(), "%s is called without forwarding the request context", sel.Sel.Name)
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
+
+func isAPIHandlerFile(name string) bool {
+	return hasPathSegments(name, "pkg", "api") && !strings.HasSuffix(name, "_test.go")
+}
+
+func looksLikeServiceCall(name string) bool {
+	switch name {
+	case "Get", "Save", "Delete", "Update", "Query":
+		return true
+	}
+	return false
+}
+
+func hasContextArg(args []ast.Expr) bool {
+	for _, arg := range args {
+		ident, ok := arg.(*ast.Ident)
+		if ok && (ident.Name == "ctx" || ident.Name == "c") {
+			return true
+		}
+		sel, ok := arg.(*ast.SelectorExpr)
+		if ok && sel.Sel.Name == "Req" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPathSegments reports whether path contains segments, consecutively
+// and in order, as whole path components -- e.g. hasPathSegments(p,
+// "pkg", "api") matches ".../pkg/api/dashboard.go" but not
+// ".../pkg/apikey/apikey.go".
+func hasPathSegments(path string, segments ...string) bool {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	for i := 0; i+len(segments) <= len(parts); i++ {
+		match := true
+		for j, seg := range segments {
+			if parts[i+j] != seg {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package analyzers
+
+import (
+	"encoding/json"
+	"go/ast"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// pluginJSON is the subset of plugin.json fields this analyzer needs.
+type pluginJSON struct {
+	Routes []struct {
+		Path string `json:"path"`
+	} `json:"routes"`
+}
+
+// PluginManifestMismatch flags a plugin backend package whose plugin.json
+// declares routes that no registered HTTP handler in the same package
+// serves, which otherwise only surfaces at runtime as a 404.
+func PluginManifestMismatch() *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name: "grafanapluginmanifest",
+		Doc:  "reports plugin.json routes with no matching registered handler",
+		Run:  runPluginManifestMismatch,
+	}
+}
+
+func runPluginManifestMismatch(pass *analysis.Pass) (interface{}, error) {
+	dirs := map[string]bool{}
+	for _, file := range pass.Files {
+		dirs[filepath.Dir(pass.Fset.File(file.Pos()).Name())] = true
+	}
+
+	for dir := range dirs {
+		manifest, ok := readPluginJSON(filepath.Join(dir, "plugin.json"))
+		if !ok {
+			continue
+		}
+
+		registered := registeredRoutes(pass, dir)
+		for _, route := range manifest.Routes {
+			if !registered[route.Path] {
+				pass.Reportf(0, "%s: plugin.json declares route %q with no registered handler", dir, route.Path)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func readPluginJSON(path string) (*pluginJSON, bool) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var manifest pluginJSON
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, false
+	}
+	return &manifest, true
+}
+
+func registeredRoutes(pass *analysis.Pass, dir string) map[string]bool {
+	routes := map[string]bool{}
+	for _, file := range pass.Files {
+		if filepath.Dir(pass.Fset.File(file.Pos()).Name()) != dir {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "HandleFunc" {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok {
+				return true
+			}
+			routes[trimQuotes(lit.Value)] = true
+			return true
+		})
+	}
+	return routes
+}
+
+func trimQuotes(s string) string {
+	if len(s) >= 2 {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
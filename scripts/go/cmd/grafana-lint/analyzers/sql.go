@@ -0,0 +1,49 @@
+package analyzers
+
+import (
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// UnboundedSQL flags pkg/services/sqlstore queries built with a SELECT
+// that has neither a WHERE clause nor a LIMIT, since those are the
+// queries most likely to scan an entire table in production.
+func UnboundedSQL() *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name: "grafanasql",
+		Doc:  "reports sqlstore SELECT queries without a WHERE or LIMIT clause",
+		Run:  runUnboundedSQL,
+	}
+}
+
+func runUnboundedSQL(pass *analysis.Pass) (interface{}, error) {
+	for _, file := range pass.Files {
+		if !hasPathSegments(pass.Fset.File(file.Pos()).Name(), "pkg", "services", "sqlstore") {
+			continue
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			lit, ok := n.(*ast.BasicLit)
+			if !ok {
+				return true
+			}
+			query := strings.Trim(lit.Value, "`\"")
+			if !isUnboundedSelect(query) {
+				return true
+			}
+			pass.Reportf(lit.Pos(), "SELECT query has no WHERE or LIMIT clause: %s", query)
+			return true
+		})
+	}
+	return nil, nil
+}
+
+func isUnboundedSelect(query string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(query))
+	if !strings.HasPrefix(upper, "SELECT ") {
+		return false
+	}
+	return !strings.Contains(upper, "WHERE") && !strings.Contains(upper, "LIMIT")
+}
@@ -0,0 +1,21 @@
+package analyzers
+
+import "testing"
+
+func TestIsUnboundedSelect(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"SELECT * FROM dashboard", true},
+		{"SELECT * FROM dashboard WHERE org_id = ?", false},
+		{"SELECT * FROM dashboard LIMIT 100", false},
+		{"INSERT INTO dashboard VALUES (?)", false},
+	}
+
+	for _, tt := range tests {
+		if got := isUnboundedSelect(tt.query); got != tt.want {
+			t.Errorf("isUnboundedSelect(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
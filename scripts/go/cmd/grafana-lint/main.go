@@ -0,0 +1,42 @@
+// Command grafana-lint wraps golangci-lint with Grafana's pinned tool
+// version and its custom analyzers, so `make lint-go` exercises the same
+// checks locally and in CI regardless of what golangci-lint happens to be
+// on $PATH. The Grafana-specific analyzers themselves are registered with
+// golangci-lint via its module plugin system (see ./plugin), since
+// golangci-lint has no programmatic Go API for registering a custom
+// *analysis.Analyzer -- only the yaml `linters-settings.custom` plugin
+// mechanism.
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "grafana-lint:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	// `go run` resolves github.com/golangci/golangci-lint/cmd/golangci-lint
+	// at the version pinned in go.mod/tools.go, rather than whatever
+	// golangci-lint happens to be on $PATH.
+	goArgs := append([]string{"run", "github.com/golangci/golangci-lint/cmd/golangci-lint", "run", "-c", ".golangci.yml"}, args...)
+
+	cmd := exec.Command("go", goArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+// Package main builds as a golangci-lint module plugin (`go build
+// -buildmode=plugin`). It is not run directly; golangci-lint loads the
+// resulting .so per the `linters-settings.custom` entry in
+// .golangci.yml and calls into the registered plugin below.
+package main
+
+import (
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/grafana/grafana/scripts/go/cmd/grafana-lint/analyzers"
+)
+
+func init() {
+	register.Plugin("grafana", New)
+}
+
+// New is the register.NewPlugin func golangci-lint calls to construct
+// the plugin; settings comes from the custom linter's yaml config, which
+// this plugin doesn't use.
+func New(settings any) (register.LinterPlugin, error) {
+	return &grafanaPlugin{}, nil
+}
+
+type grafanaPlugin struct{}
+
+func (p *grafanaPlugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{
+		analyzers.ContextPropagation(),
+		analyzers.UnboundedSQL(),
+		analyzers.PluginManifestMismatch(),
+	}, nil
+}
+
+func (p *grafanaPlugin) GetLoadMode() string {
+	return register.LoadModeSyntax
+}
@@ -7,4 +7,5 @@ import (
 	_ "github.com/mgechev/revive"
 	_ "github.com/securego/gosec"
 	_ "github.com/golangci/golangci-lint/cmd/golangci-lint"
+	_ "github.com/google/go-jsonnet/cmd/jsonnet"
 )
\ No newline at end of file